@@ -0,0 +1,354 @@
+// Package anthropic implements backend.InquiryBackend against the
+// Anthropic Messages API, translating the neutral tool schema into
+// Anthropic's input_schema shape and converting tool_use/tool_result
+// content blocks back into backend's neutral ToolCall model.
+package anthropic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+const (
+	defaultBaseURL   = "https://api.anthropic.com"
+	defaultModel     = "claude-3-5-sonnet-20241022"
+	defaultMaxTokens = 4096
+	anthropicVersion = "2023-06-01"
+)
+
+// Backend talks to the Anthropic Messages API.
+type Backend struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	maxTokens  int
+	httpClient *http.Client
+}
+
+// Option customizes a Backend at construction time.
+type Option func(*Backend)
+
+func WithModel(model string) Option {
+	return func(b *Backend) { b.model = model }
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(b *Backend) { b.baseURL = baseURL }
+}
+
+func WithMaxTokens(maxTokens int) Option {
+	return func(b *Backend) { b.maxTokens = maxTokens }
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *Backend) { b.httpClient = client }
+}
+
+func New(apiKey string, opts ...Option) *Backend {
+	b := &Backend{
+		apiKey:     apiKey,
+		baseURL:    defaultBaseURL,
+		model:      defaultModel,
+		maxTokens:  defaultMaxTokens,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// contentBlock is Anthropic's tagged-union content block, covering the
+// subset (text, tool_use, tool_result) Inquiry needs.
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string         `json:"role"`
+	Content []contentBlock `json:"content"`
+}
+
+type toolSchema struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type messagesRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Tools     []toolSchema       `json:"tools,omitempty"`
+	MaxTokens int                `json:"max_tokens"`
+	Stream    bool               `json:"stream,omitempty"`
+}
+
+type messagesResponse struct {
+	Content []contentBlock `json:"content"`
+	Error   *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (b *Backend) Chat(ctx context.Context, req backend.ChatRequest) (backend.ChatResponse, error) {
+	resp, err := b.do(ctx, b.request(req, false))
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+
+	var out backend.ChatResponse
+	var text strings.Builder
+
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "tool_use":
+			out.ToolCalls = append(out.ToolCalls, backend.ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(block.Input),
+			})
+		}
+	}
+
+	out.Content = text.String()
+	return out, nil
+}
+
+func (b *Backend) do(ctx context.Context, body messagesRequest) (messagesResponse, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return messagesResponse{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/messages", bytes.NewReader(encoded))
+	if err != nil {
+		return messagesResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", b.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+
+	httpResp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return messagesResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp messagesResponse
+	if decodeErr := json.NewDecoder(httpResp.Body).Decode(&resp); decodeErr != nil {
+		return messagesResponse{}, decodeErr
+	}
+	if resp.Error != nil {
+		return messagesResponse{}, fmt.Errorf("anthropic: %s", resp.Error.Message)
+	}
+
+	return resp, nil
+}
+
+// allToolResults reports whether every block in a message's content is a
+// tool_result, i.e. whether it's safe to append another tool_result to it
+// without mixing in unrelated user content.
+func allToolResults(blocks []contentBlock) bool {
+	for _, b := range blocks {
+		if b.Type != "tool_result" {
+			return false
+		}
+	}
+	return len(blocks) > 0
+}
+
+// request translates a neutral ChatRequest into an Anthropic messages
+// request, pulling any system messages out into the top-level System field
+// since Anthropic has no "system" message role.
+func (b *Backend) request(req backend.ChatRequest, stream bool) messagesRequest {
+	var system strings.Builder
+	messages := make([]anthropicMessage, 0, len(req.Messages))
+
+	for _, m := range req.Messages {
+		switch m.Role {
+		case backend.RoleSystem:
+			if system.Len() > 0 {
+				system.WriteString("\n")
+			}
+			system.WriteString(m.Content)
+
+		case backend.RoleAssistant:
+			blocks := make([]contentBlock, 0, 1+len(m.ToolCalls))
+			if m.Content != "" {
+				blocks = append(blocks, contentBlock{Type: "text", Text: m.Content})
+			}
+			for _, tc := range m.ToolCalls {
+				blocks = append(blocks, contentBlock{
+					Type:  "tool_use",
+					ID:    tc.ID,
+					Name:  tc.Name,
+					Input: json.RawMessage(tc.Arguments),
+				})
+			}
+			messages = append(messages, anthropicMessage{Role: "assistant", Content: blocks})
+
+		case backend.RoleTool:
+			// Anthropic requires strictly alternating user/assistant
+			// messages, so every tool_result following a multi-tool-call
+			// round must land in the same "user" message rather than one
+			// message per result.
+			block := contentBlock{
+				Type:      "tool_result",
+				ToolUseID: m.ToolCallID,
+				Content:   m.Content,
+			}
+			if n := len(messages); n > 0 && messages[n-1].Role == "user" && allToolResults(messages[n-1].Content) {
+				messages[n-1].Content = append(messages[n-1].Content, block)
+			} else {
+				messages = append(messages, anthropicMessage{Role: "user", Content: []contentBlock{block}})
+			}
+
+		default: // backend.RoleUser
+			messages = append(messages, anthropicMessage{
+				Role:    "user",
+				Content: []contentBlock{{Type: "text", Text: m.Content}},
+			})
+		}
+	}
+
+	tools := make([]toolSchema, len(req.Tools))
+	for idx, t := range req.Tools {
+		var schema map[string]any
+		encoded, _ := json.Marshal(t.Parameters)
+		_ = json.Unmarshal(encoded, &schema)
+
+		tools[idx] = toolSchema{
+			Name:        t.Name,
+			Description: t.Description,
+			InputSchema: schema,
+		}
+	}
+
+	return messagesRequest{
+		Model:     b.model,
+		System:    system.String(),
+		Messages:  messages,
+		Tools:     tools,
+		MaxTokens: b.maxTokens,
+		Stream:    stream,
+	}
+}
+
+// sseEvent is the subset of Anthropic's streaming event payload Inquiry
+// needs to reassemble text and tool-call deltas.
+type sseEvent struct {
+	Type         string `json:"type"`
+	Index        int    `json:"index"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content_block"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+}
+
+func (b *Backend) ChatStream(ctx context.Context, req backend.ChatRequest) (<-chan backend.StreamEvent, error) {
+	encoded, err := json.Marshal(b.request(req, true))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/messages", bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", b.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	httpResp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
+		return nil, fmt.Errorf("anthropic: unexpected status %s", httpResp.Status)
+	}
+
+	out := make(chan backend.StreamEvent)
+
+	go func() {
+		defer close(out)
+		defer httpResp.Body.Close()
+
+		toolCallsByIndex := map[int]*backend.ToolCall{}
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		var eventData string
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case strings.HasPrefix(line, "data:"):
+				eventData = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			case line == "":
+				if eventData == "" {
+					continue
+				}
+
+				var event sseEvent
+				if unmarshalErr := json.Unmarshal([]byte(eventData), &event); unmarshalErr != nil {
+					out <- backend.StreamEvent{Kind: backend.StreamDone, Err: unmarshalErr}
+					return
+				}
+				eventData = ""
+
+				switch event.Type {
+				case "content_block_start":
+					if event.ContentBlock.Type == "tool_use" {
+						toolCallsByIndex[event.Index] = &backend.ToolCall{
+							ID:   event.ContentBlock.ID,
+							Name: event.ContentBlock.Name,
+						}
+					}
+				case "content_block_delta":
+					switch event.Delta.Type {
+					case "text_delta":
+						out <- backend.StreamEvent{Kind: backend.StreamContent, Content: event.Delta.Text}
+					case "input_json_delta":
+						if acc, ok := toolCallsByIndex[event.Index]; ok {
+							acc.Arguments += event.Delta.PartialJSON
+							out <- backend.StreamEvent{Kind: backend.StreamToolCall, ToolCall: *acc}
+						}
+					}
+				}
+			}
+		}
+
+		if scanErr := scanner.Err(); scanErr != nil {
+			out <- backend.StreamEvent{Kind: backend.StreamDone, Err: scanErr}
+			return
+		}
+
+		out <- backend.StreamEvent{Kind: backend.StreamDone}
+	}()
+
+	return out, nil
+}
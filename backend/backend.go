@@ -0,0 +1,100 @@
+// Package backend defines the provider-neutral shape Inquiry talks to, so
+// the model driving a conversation can be swapped (OpenAI, Anthropic,
+// Ollama, or any OpenAI-compatible server) without touching the tool-call
+// loop or yaegi execution in cmd/playground.
+package backend
+
+import (
+	"context"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// Role identifies who authored a Message, independent of any provider's
+// own naming for it.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// ToolCall is a single invocation of a registered function, as requested by
+// the model. Arguments is the raw JSON object the model produced, keyed by
+// parameter name.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// Message is one entry in a conversation, in the provider-neutral shape
+// Inquiry keeps its history in. ToolCalls is set on an assistant message
+// that chose to call one or more tools; ToolCallID and Name are set on the
+// Message sent back in reply to one of those calls.
+type Message struct {
+	Role       Role
+	Content    string
+	ToolCalls  []ToolCall
+	ToolCallID string
+	Name       string
+}
+
+// ToolDefinition describes a single callable function to the model.
+// Parameters is a standard JSON Schema object; each backend is responsible
+// for translating it into whatever shape its own API expects (e.g.
+// Anthropic's input_schema).
+type ToolDefinition struct {
+	Name        string
+	Description string
+	Parameters  jsonschema.Definition
+}
+
+// ChatRequest is everything a backend needs to produce the next turn of a
+// conversation.
+type ChatRequest struct {
+	Messages    []Message
+	Tools       []ToolDefinition
+	ToolChoice  string
+	Temperature float32
+	TopP        float32
+}
+
+// ChatResponse is a complete, non-streamed model turn: either a final
+// answer (Content non-empty) or one or more tool calls to execute.
+type ChatResponse struct {
+	Content   string
+	ToolCalls []ToolCall
+}
+
+// StreamEventKind distinguishes the events a ChatStream emits.
+type StreamEventKind int
+
+const (
+	// StreamContent carries a chunk of assistant-authored text.
+	StreamContent StreamEventKind = iota
+	// StreamToolCall carries a tool call as reassembled so far out of
+	// whatever per-provider chunking the backend received; the last event
+	// seen for a given ToolCall.ID holds the complete call.
+	StreamToolCall
+	// StreamDone marks the end of this turn. Err is set if the stream
+	// ended because of a failure rather than a clean model turn.
+	StreamDone
+)
+
+// StreamEvent is one event out of InquiryBackend.ChatStream.
+type StreamEvent struct {
+	Kind     StreamEventKind
+	Content  string
+	ToolCall ToolCall
+	Err      error
+}
+
+// InquiryBackend is the interface Inquiry drives a conversation through.
+// Implementations live in sibling packages, one per provider.
+type InquiryBackend interface {
+	Chat(ctx context.Context, req ChatRequest) (ChatResponse, error)
+	ChatStream(ctx context.Context, req ChatRequest) (<-chan StreamEvent, error)
+}
@@ -0,0 +1,279 @@
+// Package ollama implements backend.InquiryBackend against a local Ollama
+// server's native /api/chat endpoint.
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+const (
+	defaultBaseURL = "http://localhost:11434"
+	defaultModel   = "llama3"
+)
+
+// Backend talks to a local Ollama server.
+type Backend struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+// Option customizes a Backend at construction time.
+type Option func(*Backend)
+
+func WithModel(model string) Option {
+	return func(b *Backend) { b.model = model }
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(b *Backend) { b.baseURL = baseURL }
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *Backend) { b.httpClient = client }
+}
+
+func New(opts ...Option) *Backend {
+	b := &Backend{
+		baseURL:    defaultBaseURL,
+		model:      defaultModel,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+type chatTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Parameters  map[string]any `json:"parameters"`
+	} `json:"function"`
+}
+
+type chatToolCall struct {
+	Function struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatMessage struct {
+	Role      string         `json:"role"`
+	Content   string         `json:"content"`
+	ToolCalls []chatToolCall `json:"tool_calls,omitempty"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Tools    []chatTool    `json:"tools,omitempty"`
+	Stream   bool          `json:"stream"`
+}
+
+type chatResponse struct {
+	Message chatMessage `json:"message"`
+	Done    bool        `json:"done"`
+	Error   string      `json:"error,omitempty"`
+}
+
+func (b *Backend) Chat(ctx context.Context, req backend.ChatRequest) (backend.ChatResponse, error) {
+	encoded, err := json.Marshal(b.request(req, false))
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/chat", bytes.NewReader(encoded))
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp chatResponse
+	if decodeErr := json.NewDecoder(httpResp.Body).Decode(&resp); decodeErr != nil {
+		return backend.ChatResponse{}, decodeErr
+	}
+	if resp.Error != "" {
+		return backend.ChatResponse{}, fmt.Errorf("ollama: %s", resp.Error)
+	}
+
+	toolCalls, err := toolCallsFromOllama(resp.Message.ToolCalls)
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+
+	return backend.ChatResponse{
+		Content:   resp.Message.Content,
+		ToolCalls: toolCalls,
+	}, nil
+}
+
+// ChatStream reads Ollama's newline-delimited JSON stream. Ollama doesn't
+// chunk tool-call arguments the way OpenAI/Anthropic do: a tool call only
+// ever shows up whole, on the message that carries it, so each one is
+// emitted as a single StreamToolCall event rather than reassembled
+// incrementally.
+func (b *Backend) ChatStream(ctx context.Context, req backend.ChatRequest) (<-chan backend.StreamEvent, error) {
+	encoded, err := json.Marshal(b.request(req, true))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/chat", bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
+		return nil, fmt.Errorf("ollama: unexpected status %s", httpResp.Status)
+	}
+
+	out := make(chan backend.StreamEvent)
+
+	go func() {
+		defer close(out)
+		defer httpResp.Body.Close()
+
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			var resp chatResponse
+			if unmarshalErr := json.Unmarshal(line, &resp); unmarshalErr != nil {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: unmarshalErr}
+				return
+			}
+			if resp.Error != "" {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: fmt.Errorf("ollama: %s", resp.Error)}
+				return
+			}
+
+			if resp.Message.Content != "" {
+				out <- backend.StreamEvent{Kind: backend.StreamContent, Content: resp.Message.Content}
+			}
+
+			toolCalls, err := toolCallsFromOllama(resp.Message.ToolCalls)
+			if err != nil {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: err}
+				return
+			}
+			for _, tc := range toolCalls {
+				out <- backend.StreamEvent{Kind: backend.StreamToolCall, ToolCall: tc}
+			}
+
+			if resp.Done {
+				break
+			}
+		}
+
+		if scanErr := scanner.Err(); scanErr != nil {
+			out <- backend.StreamEvent{Kind: backend.StreamDone, Err: scanErr}
+			return
+		}
+
+		out <- backend.StreamEvent{Kind: backend.StreamDone}
+	}()
+
+	return out, nil
+}
+
+func (b *Backend) request(req backend.ChatRequest, stream bool) chatRequest {
+	messages := make([]chatMessage, len(req.Messages))
+	for idx, m := range req.Messages {
+		messages[idx] = chatMessage{
+			Role:      string(m.Role),
+			Content:   m.Content,
+			ToolCalls: toolCallsToOllama(m.ToolCalls),
+		}
+	}
+
+	tools := make([]chatTool, len(req.Tools))
+	for idx, t := range req.Tools {
+		var schema map[string]any
+		encoded, _ := json.Marshal(t.Parameters)
+		_ = json.Unmarshal(encoded, &schema)
+
+		tools[idx].Type = "function"
+		tools[idx].Function.Name = t.Name
+		tools[idx].Function.Description = t.Description
+		tools[idx].Function.Parameters = schema
+	}
+
+	return chatRequest{
+		Model:    b.model,
+		Messages: messages,
+		Tools:    tools,
+		Stream:   stream,
+	}
+}
+
+// toolCallsToOllama converts backend.ToolCall (where Arguments is a JSON
+// string, per the neutral schema) into Ollama's native chatToolCall shape,
+// whose Arguments field is a decoded map rather than a raw string.
+func toolCallsToOllama(calls []backend.ToolCall) []chatToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	out := make([]chatToolCall, len(calls))
+	for idx, c := range calls {
+		var args map[string]any
+		_ = json.Unmarshal([]byte(c.Arguments), &args)
+
+		out[idx].Function.Name = c.Name
+		out[idx].Function.Arguments = args
+	}
+	return out
+}
+
+// toolCallsFromOllama converts Ollama's tool calls into backend.ToolCall,
+// synthesizing an ID for each: Ollama's native API doesn't assign one,
+// but Inquiry dedups/accumulates streamed tool calls by ToolCall.ID, so
+// leaving it blank would collapse every call in a multi-tool-call round
+// down to the last one.
+func toolCallsFromOllama(calls []chatToolCall) ([]backend.ToolCall, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	out := make([]backend.ToolCall, len(calls))
+	for idx, c := range calls {
+		args, err := json.Marshal(c.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		out[idx] = backend.ToolCall{
+			ID:        fmt.Sprintf("ollama-call-%d-%s", idx, c.Function.Name),
+			Name:      c.Function.Name,
+			Arguments: string(args),
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,283 @@
+// Package openai implements backend.InquiryBackend on top of
+// sashabaranov/go-openai. Because it accepts a configurable BaseURL, it
+// also works against any OpenAI-compatible server such as LocalAI or
+// llama.cpp-server.
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+// Backend wraps an *openai.Client to satisfy backend.InquiryBackend.
+type Backend struct {
+	client                   *openai.Client
+	model                    string
+	useLegacyFunctionCalling bool
+}
+
+// Option customizes a Backend at construction time.
+type Option func(*Backend)
+
+// WithModel overrides the default model (openai.GPT3Dot5Turbo16K0613).
+func WithModel(model string) Option {
+	return func(b *Backend) {
+		b.model = model
+	}
+}
+
+// WithLegacyFunctionCalling switches the Backend onto the deprecated
+// functions/function_call fields instead of tools/tool_calls, for models or
+// OpenAI-compatible servers that don't yet speak the current API.
+func WithLegacyFunctionCalling(enabled bool) Option {
+	return func(b *Backend) {
+		b.useLegacyFunctionCalling = enabled
+	}
+}
+
+// New builds a Backend against the default OpenAI API. Use WithBaseURL (via
+// a pre-configured client config) to point it at a compatible server
+// instead.
+func New(client *openai.Client, opts ...Option) *Backend {
+	b := &Backend{client: client, model: openai.GPT3Dot5Turbo16K0613}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// NewWithBaseURL builds a Backend against an OpenAI-compatible server
+// (LocalAI, llama.cpp-server, ...) reachable at baseURL.
+func NewWithBaseURL(apiKey, baseURL string, opts ...Option) *Backend {
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = baseURL
+	return New(openai.NewClientWithConfig(cfg), opts...)
+}
+
+func (b *Backend) Chat(ctx context.Context, req backend.ChatRequest) (backend.ChatResponse, error) {
+	result, err := b.client.CreateChatCompletion(ctx, b.request(req, false))
+	if err != nil {
+		return backend.ChatResponse{}, err
+	}
+
+	if len(result.Choices) == 0 {
+		return backend.ChatResponse{}, errors.New("openai: no choices returned")
+	}
+
+	message := result.Choices[0].Message
+
+	if b.useLegacyFunctionCalling && message.FunctionCall != nil {
+		return backend.ChatResponse{ToolCalls: []backend.ToolCall{toolCallFromLegacy(*message.FunctionCall)}}, nil
+	}
+
+	return backend.ChatResponse{
+		Content:   message.Content,
+		ToolCalls: toolCallsFromOpenAI(message.ToolCalls),
+	}, nil
+}
+
+func (b *Backend) ChatStream(ctx context.Context, req backend.ChatRequest) (<-chan backend.StreamEvent, error) {
+	stream, err := b.client.CreateChatCompletionStream(ctx, b.request(req, true))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan backend.StreamEvent)
+
+	go func() {
+		defer close(out)
+		defer stream.Close()
+
+		toolCallsByIndex := map[int]*backend.ToolCall{}
+
+		for {
+			chunk, recvErr := stream.Recv()
+			if errors.Is(recvErr, io.EOF) {
+				break
+			}
+			if recvErr != nil {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: recvErr}
+				return
+			}
+
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			delta := chunk.Choices[0].Delta
+
+			if delta.Content != "" {
+				out <- backend.StreamEvent{Kind: backend.StreamContent, Content: delta.Content}
+			}
+
+			if b.useLegacyFunctionCalling && delta.FunctionCall != nil {
+				acc, ok := toolCallsByIndex[0]
+				if !ok {
+					acc = &backend.ToolCall{}
+					toolCallsByIndex[0] = acc
+				}
+				acc.Name += delta.FunctionCall.Name
+				acc.Arguments += delta.FunctionCall.Arguments
+				out <- backend.StreamEvent{Kind: backend.StreamToolCall, ToolCall: *acc}
+			}
+
+			for _, tc := range delta.ToolCalls {
+				idx := 0
+				if tc.Index != nil {
+					idx = *tc.Index
+				}
+
+				acc, ok := toolCallsByIndex[idx]
+				if !ok {
+					acc = &backend.ToolCall{}
+					toolCallsByIndex[idx] = acc
+				}
+				if tc.ID != "" {
+					acc.ID = tc.ID
+				}
+				acc.Name += tc.Function.Name
+				acc.Arguments += tc.Function.Arguments
+
+				out <- backend.StreamEvent{Kind: backend.StreamToolCall, ToolCall: *acc}
+			}
+		}
+
+		out <- backend.StreamEvent{Kind: backend.StreamDone}
+	}()
+
+	return out, nil
+}
+
+// request translates a neutral ChatRequest into the shape the go-openai
+// client expects, using tools/tool_calls unless useLegacyFunctionCalling is
+// set, in which case it uses functions/function_call instead.
+func (b *Backend) request(req backend.ChatRequest, stream bool) openai.ChatCompletionRequest {
+	messages := make([]openai.ChatCompletionMessage, len(req.Messages))
+	for idx, m := range req.Messages {
+		if b.useLegacyFunctionCalling {
+			messages[idx] = b.legacyMessage(m)
+			continue
+		}
+
+		messages[idx] = openai.ChatCompletionMessage{
+			Role:       string(m.Role),
+			Content:    m.Content,
+			Name:       m.Name,
+			ToolCallID: m.ToolCallID,
+			ToolCalls:  toolCallsToOpenAI(m.ToolCalls),
+		}
+	}
+
+	funcDefs := make([]openai.FunctionDefinition, len(req.Tools))
+	for idx, t := range req.Tools {
+		funcDefs[idx] = openai.FunctionDefinition{
+			Name:        t.Name,
+			Description: t.Description,
+			Parameters:  t.Parameters,
+		}
+	}
+
+	out := openai.ChatCompletionRequest{
+		Model:       b.model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		Stream:      stream,
+	}
+
+	if b.useLegacyFunctionCalling {
+		out.Functions = funcDefs
+		return out
+	}
+
+	tools := make([]openai.Tool, len(funcDefs))
+	for idx := range funcDefs {
+		tools[idx] = openai.Tool{Type: openai.ToolTypeFunction, Function: &funcDefs[idx]}
+	}
+
+	toolChoice := req.ToolChoice
+	if toolChoice == "" && len(tools) > 0 {
+		toolChoice = "auto"
+	}
+
+	out.Tools = tools
+	out.ToolChoice = toolChoice
+
+	return out
+}
+
+func toolCallsToOpenAI(calls []backend.ToolCall) []openai.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	out := make([]openai.ToolCall, len(calls))
+	for idx, c := range calls {
+		out[idx] = openai.ToolCall{
+			ID:   c.ID,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      c.Name,
+				Arguments: c.Arguments,
+			},
+		}
+	}
+	return out
+}
+
+// legacyMessage translates a neutral Message into the deprecated
+// functions/function_call shape: tool results are sent back as
+// ChatMessageRoleFunction rather than ChatMessageRoleTool, and an assistant
+// message's (single) tool call is carried in FunctionCall rather than
+// ToolCalls.
+func (b *Backend) legacyMessage(m backend.Message) openai.ChatCompletionMessage {
+	if m.Role == backend.RoleTool {
+		return openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleFunction,
+			Content: m.Content,
+			Name:    m.Name,
+		}
+	}
+
+	out := openai.ChatCompletionMessage{
+		Role:    string(m.Role),
+		Content: m.Content,
+	}
+
+	if len(m.ToolCalls) > 0 {
+		out.FunctionCall = &openai.FunctionCall{
+			Name:      m.ToolCalls[0].Name,
+			Arguments: m.ToolCalls[0].Arguments,
+		}
+	}
+
+	return out
+}
+
+func toolCallFromLegacy(call openai.FunctionCall) backend.ToolCall {
+	return backend.ToolCall{
+		ID:        "legacy-call-0",
+		Name:      call.Name,
+		Arguments: call.Arguments,
+	}
+}
+
+func toolCallsFromOpenAI(calls []openai.ToolCall) []backend.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	out := make([]backend.ToolCall, len(calls))
+	for idx, c := range calls {
+		out[idx] = backend.ToolCall{
+			ID:        c.ID,
+			Name:      c.Function.Name,
+			Arguments: c.Function.Arguments,
+		}
+	}
+	return out
+}
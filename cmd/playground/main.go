@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,13 +10,19 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/sashabaranov/go-openai/jsonschema"
 	"github.com/traefik/yaegi/interp"
 	"github.com/traefik/yaegi/stdlib"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+	openaibackend "github.com/verygoodsoftwarenotvirus/wesley/backend/openai"
+	"github.com/verygoodsoftwarenotvirus/wesley/conversation"
 )
 
 const (
@@ -23,6 +30,80 @@ const (
 	berlinLong = "13.405"
 )
 
+// maxParallelToolCalls bounds how many yaegi evaluations Answer will run
+// concurrently for a single round of tool calls.
+const maxParallelToolCalls = 4
+
+// allowedStdlibPackages is the default yaegi symbol allowlist: enough of
+// the stdlib for typical tool bodies, without the packages that would let
+// a model-chosen script touch the process, filesystem, or network.
+var allowedStdlibPackages = []string{"fmt/fmt", "strings/strings", "strconv/strconv", "math/math", "time/time"}
+
+// ExecutionPolicy bounds how evalToolCall runs a model-chosen yaegi
+// script: which stdlib symbols it can reach, how long a single call may
+// run, how many tool-call rounds AnswerStream will drive before giving
+// up, and whether to capture the script's stdout/stderr.
+type ExecutionPolicy struct {
+	// Symbols is the yaegi symbol table scripts are interpreted against,
+	// in addition to the caller's own registered functions (always
+	// reachable via the "provided" import).
+	Symbols interp.Exports
+
+	// Timeout bounds a single evalToolCall call. Zero means no timeout.
+	Timeout time.Duration
+
+	// MaxSteps bounds how many tool-call rounds AnswerStream will drive
+	// for a single question before giving up. Zero means no limit.
+	MaxSteps int
+
+	// CaptureOutput, if true, captures stdout/stderr produced while
+	// evaluating a script and appends them to the tool result.
+	CaptureOutput bool
+}
+
+// DefaultExecutionPolicy is what NewInquiry uses unless overridden with
+// WithExecutionPolicy: a five-second per-call timeout, 25 tool-call
+// rounds, and the package allowlist in allowedStdlibPackages.
+func DefaultExecutionPolicy() ExecutionPolicy {
+	symbols := interp.Exports{}
+	for _, pkg := range allowedStdlibPackages {
+		if syms, ok := stdlib.Symbols[pkg]; ok {
+			symbols[pkg] = syms
+		}
+	}
+
+	return ExecutionPolicy{
+		Symbols:  symbols,
+		Timeout:  5 * time.Second,
+		MaxSteps: 25,
+	}
+}
+
+// ToolExecError describes a failed yaegi evaluation of a single tool
+// call. executeToolCalls feeds its Error() string back into the message
+// history as the tool's result rather than failing the whole Answer, so
+// the model gets a chance to recover instead of the conversation just
+// dying.
+type ToolExecError struct {
+	FunctionName string
+	Err          error
+	Stdout       string
+	Stderr       string
+}
+
+func (e *ToolExecError) Error() string {
+	msg := fmt.Sprintf("wesley: %s: %v", e.FunctionName, e.Err)
+	if e.Stdout != "" {
+		msg += fmt.Sprintf("\n[stdout]: %s", e.Stdout)
+	}
+	if e.Stderr != "" {
+		msg += fmt.Sprintf("\n[stderr]: %s", e.Stderr)
+	}
+	return msg
+}
+
+func (e *ToolExecError) Unwrap() error { return e.Err }
+
 func LookupCityLatitude(cityName string) string {
 	switch strings.TrimSpace(strings.ToLower(cityName)) {
 	case "berlin":
@@ -53,11 +134,15 @@ func LookupWeatherByCoordinate(lat, long string) string {
 func main() {
 	client := openai.NewClient(os.Getenv("OPENAI_API_KEY"))
 
-	i := NewInquiry(client)
+	i := NewInquiry(openaibackend.New(client))
 
-	i.AddFunctionToRepertoire(LookupCityLatitude, "returns the latitude of a given city")
-	i.AddFunctionToRepertoire(LookupCityLongitude, "returns the longitude of a given city")
-	i.AddFunctionToRepertoire(LookupWeatherByCoordinate, "returns the weather for a given latitude and longitude")
+	i.AddFunctionToRepertoire(LookupCityLatitude, "returns the latitude of a given city",
+		WithParamNames("city"))
+	i.AddFunctionToRepertoire(LookupCityLongitude, "returns the longitude of a given city",
+		WithParamNames("city"))
+	i.AddFunctionToRepertoire(LookupWeatherByCoordinate, "returns the weather for a given latitude and longitude",
+		WithParamNames("latitude", "longitude"),
+		WithParamDescriptions("latitude in decimal degrees", "longitude in decimal degrees"))
 
 	ctx := context.Background()
 	answer, err := i.Answer(ctx, "What is the weather like in Berlin right now?")
@@ -68,26 +153,128 @@ func main() {
 	println(answer)
 }
 
-func jsonSchemaTypeForGoType(t string) jsonschema.DataType {
-	switch t {
-	case "string":
-		return jsonschema.String
-	case "struct":
-		return jsonschema.Object
-	case "float32", "float64":
-		return jsonschema.Number
-	case "int", "int8", "int16", "int32", "int64":
-		return jsonschema.Integer
-	case "[]":
-		return jsonschema.Array
-	case "bool":
-		return jsonschema.Boolean
+// wesleyTag is the parsed form of a `wesley:"..."` struct tag, which lets a
+// parameter struct field describe itself to the model beyond what the json
+// tag and Go type already say.
+type wesleyTag struct {
+	description string
+	enum        []string
+	required    bool
+}
+
+// parseWesleyTag parses a `wesley:"desc=...,enum=a|b|c,required"` tag value.
+// Unknown segments are ignored so the tag can grow without breaking callers.
+func parseWesleyTag(raw string) wesleyTag {
+	var tag wesleyTag
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "required":
+			tag.required = true
+		case strings.HasPrefix(part, "desc="):
+			tag.description = strings.TrimPrefix(part, "desc=")
+		case strings.HasPrefix(part, "enum="):
+			tag.enum = strings.Split(strings.TrimPrefix(part, "enum="), "|")
+		}
+	}
+
+	return tag
+}
+
+// jsonFieldName reports the name a struct field is addressed by in JSON,
+// honoring a `json:"name,omitempty"` tag and falling back to the Go field
+// name. ok is false for fields explicitly excluded with `json:"-"`.
+func jsonFieldName(field reflect.StructField) (name string, omitempty, ok bool) {
+	tag, hasTag := field.Tag.Lookup("json")
+	if !hasTag {
+		return field.Name, false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return "", false, false
+	}
+
+	name = field.Name
+	if parts[0] != "" {
+		name = parts[0]
+	}
+
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, true
+}
+
+// jsonSchemaDefinitionForType recursively builds the jsonschema.Definition
+// matching a reflected Go type: structs walk their exported fields, slices
+// and arrays get a populated Items definition, and pointers describe their
+// element type (pointers are treated as optional by the caller).
+func jsonSchemaDefinitionForType(t reflect.Type) jsonschema.Definition {
+	switch t.Kind() {
+	case reflect.String:
+		return jsonschema.Definition{Type: jsonschema.String}
+	case reflect.Bool:
+		return jsonschema.Definition{Type: jsonschema.Boolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonschema.Definition{Type: jsonschema.Integer}
+	case reflect.Float32, reflect.Float64:
+		return jsonschema.Definition{Type: jsonschema.Number}
+	case reflect.Slice, reflect.Array:
+		items := jsonSchemaDefinitionForType(t.Elem())
+		return jsonschema.Definition{Type: jsonschema.Array, Items: &items}
+	case reflect.Ptr:
+		return jsonSchemaDefinitionForType(t.Elem())
+	case reflect.Struct:
+		return jsonSchemaDefinitionForStruct(t)
 	default:
-		panic("unknown type")
+		panic(fmt.Sprintf("wesley: unsupported parameter type %s", t.String()))
+	}
+}
+
+// jsonSchemaDefinitionForStruct walks a struct's exported fields, reading
+// json tags for naming and wesley tags for descriptions/enums/required
+// overrides.
+func jsonSchemaDefinitionForStruct(t reflect.Type) jsonschema.Definition {
+	def := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{},
 	}
+
+	for f := 0; f < t.NumField(); f++ {
+		field := t.Field(f)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, omitempty, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		prop := jsonSchemaDefinitionForType(field.Type)
+		tag := parseWesleyTag(field.Tag.Get("wesley"))
+		prop.Description = tag.description
+		prop.Enum = tag.enum
+		def.Properties[name] = prop
+
+		if field.Type.Kind() != reflect.Ptr && (!omitempty || tag.required) {
+			def.Required = append(def.Required, name)
+		}
+	}
+
+	return def
 }
 
-func openAIDefinitionForFunction(t any, description string) openai.FunctionDefinition {
+// toolDefinitionForFunction builds the backend.ToolDefinition the model
+// sees for f, using paramNames/paramDescriptions (positional, matching f's
+// signature) in place of the bare parameter index when provided.
+func toolDefinitionForFunction(t any, description string, paramNames, paramDescriptions []string) backend.ToolDefinition {
 	if k := reflect.ValueOf(t).Kind(); k != reflect.Func {
 		panic("invalid input type")
 	}
@@ -103,167 +290,768 @@ func openAIDefinitionForFunction(t any, description string) openai.FunctionDefin
 		Properties: map[string]jsonschema.Definition{},
 		Required:   []string{},
 	}
-	x := openai.FunctionDefinition{
+
+	typ := reflect.TypeOf(t)
+
+	for idx := 0; idx < typ.NumIn(); idx++ {
+		pt := typ.In(idx)
+
+		prop := jsonSchemaDefinitionForType(pt)
+		if idx < len(paramDescriptions) {
+			prop.Description = paramDescriptions[idx]
+		}
+
+		params.Properties[paramNames[idx]] = prop
+		if pt.Kind() != reflect.Ptr {
+			params.Required = append(params.Required, paramNames[idx])
+		}
+	}
+
+	return backend.ToolDefinition{
 		Name:        funcName,
 		Description: description,
+		Parameters:  params,
 	}
+}
 
-	typ := reflect.TypeOf(t)
+// goLiteralForValue converts a JSON value back into Go source text matching
+// t, so it can be spliced straight into a yaegi call expression. It mirrors
+// jsonSchemaDefinitionForType: strings are quoted, slices/arrays become
+// composite literals of their element type, pointers become nil or a
+// pointer to a synthesized literal, and structs are rebuilt field by field
+// using the same json-tag naming jsonSchemaDefinitionForStruct used to
+// describe them.
+func goLiteralForValue(raw json.RawMessage, t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if len(raw) == 0 || string(raw) == "null" {
+			return "nil", nil
+		}
+		inner, err := goLiteralForValue(raw, t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("func() *%s { v := %s; return &v }()", goTypeExpr(t.Elem()), inner), nil
 
-	parameterCount := typ.NumIn()
-	for i := 0; i < parameterCount; i++ {
-		pn := fmt.Sprintf("%d", i)
-		params.Properties[pn] = jsonschema.Definition{
-			Type:        jsonSchemaTypeForGoType(typ.In(i).String()),
-			Description: "",
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%q", s), nil
+
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(n, 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return "", err
+		}
+
+		literals := make([]string, len(items))
+		for idx, item := range items {
+			literal, err := goLiteralForValue(item, t.Elem())
+			if err != nil {
+				return "", err
+			}
+			literals[idx] = literal
+		}
+
+		if t.Kind() == reflect.Array {
+			return fmt.Sprintf("[%d]%s{%s}", t.Len(), goTypeExpr(t.Elem()), strings.Join(literals, ", ")), nil
+		}
+
+		return fmt.Sprintf("[]%s{%s}", goTypeExpr(t.Elem()), strings.Join(literals, ", ")), nil
+
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return "", err
+		}
+
+		parts := make([]string, 0, t.NumField())
+		for f := 0; f < t.NumField(); f++ {
+			field := t.Field(f)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+
+			raw, present := fields[name]
+			if !present {
+				continue
+			}
+
+			literal, err := goLiteralForValue(raw, field.Type)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, fmt.Sprintf("%s: %s", field.Name, literal))
 		}
-		params.Required = append(params.Required, pn)
-	}
 
-	x.Parameters = params
+		return fmt.Sprintf("%s{%s}", goTypeExpr(t), strings.Join(parts, ", ")), nil
 
-	return x
+	default:
+		return "", fmt.Errorf("wesley: unsupported parameter type %s", t.String())
+	}
+}
+
+// goTypeExpr names t the way evalToolCall's generated script can actually
+// reference it: yaegi only ever imports the caller's own package under
+// "provided" (see Inquiry.replExports), so a struct type must be written
+// as provided.<Name> rather than its real <pkg>.<Name> — AddFunctionToRepertoire
+// registers every struct type reachable from a registered function's
+// parameters under that same "provided" symbol table for exactly this
+// reason.
+func goTypeExpr(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct:
+		return "provided." + t.Name()
+	case reflect.Ptr:
+		return "*" + goTypeExpr(t.Elem())
+	default:
+		return t.String()
+	}
 }
 
 type availableInquiryFunction struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
-	FunctionDef openai.FunctionDefinition
+	FunctionDef backend.ToolDefinition
+
+	// paramNames and paramTypes are positional, matching the reflected
+	// function signature, and let evalToolCall turn the named arguments the
+	// model sends back into a yaegi call expression in the right order.
+	paramNames []string
+	paramTypes []reflect.Type
+}
+
+// FunctionOption customizes how AddFunctionToRepertoire describes a Go
+// function's parameters to the model.
+type FunctionOption func(*functionOptions)
+
+type functionOptions struct {
+	paramNames        []string
+	paramDescriptions []string
+}
+
+// WithParamNames supplies the parameter names the model should see, in
+// place of the default "param0", "param1", ... Names are positional and
+// must match the order of the function's Go parameters.
+func WithParamNames(names ...string) FunctionOption {
+	return func(o *functionOptions) {
+		o.paramNames = names
+	}
+}
+
+// WithParamDescriptions supplies a per-parameter description, positional
+// and matching the order of the function's Go parameters.
+func WithParamDescriptions(descriptions ...string) FunctionOption {
+	return func(o *functionOptions) {
+		o.paramDescriptions = descriptions
+	}
 }
 
 type Inquiry struct {
-	messages           []openai.ChatCompletionMessage
+	messages           []backend.Message
 	functionRepertoire []availableInquiryFunction
-	openaiClient       *openai.Client
+	llmBackend         backend.InquiryBackend
 	replExports        map[string]reflect.Value
+	policy             ExecutionPolicy
+
+	store          conversation.ConversationStore
+	summaryBackend backend.InquiryBackend
+	contextWindow  int
+}
+
+// InquiryOption customizes an Inquiry at construction time.
+type InquiryOption func(*Inquiry)
+
+// WithExecutionPolicy overrides the default ExecutionPolicy (see
+// DefaultExecutionPolicy) that bounds how yaegi runs model-chosen tool
+// calls.
+func WithExecutionPolicy(policy ExecutionPolicy) InquiryOption {
+	return func(i *Inquiry) {
+		i.policy = policy
+	}
+}
+
+// WithConversationStore gives Ask somewhere to load and save multi-turn
+// conversation state. Ask returns an error if this isn't set.
+func WithConversationStore(store conversation.ConversationStore) InquiryOption {
+	return func(i *Inquiry) {
+		i.store = store
+	}
+}
+
+// WithSummaryBackend overrides the backend Ask uses to summarize older
+// turns once a conversation approaches WithContextWindow's token budget.
+// Defaults to the same backend NewInquiry was given, but a cheaper/faster
+// model is usually a better fit for summarization than the one driving
+// the conversation itself.
+func WithSummaryBackend(b backend.InquiryBackend) InquiryOption {
+	return func(i *Inquiry) {
+		i.summaryBackend = b
+	}
 }
 
-func NewInquiry(openaiClient *openai.Client) *Inquiry {
-	return &Inquiry{
-		openaiClient:       openaiClient,
+// WithContextWindow sets the token budget (as estimated by
+// conversation.EstimateTokens) Ask watches to decide when to summarize a
+// conversation's older turns. Zero (the default) disables summarization.
+func WithContextWindow(tokens int) InquiryOption {
+	return func(i *Inquiry) {
+		i.contextWindow = tokens
+	}
+}
+
+// NewInquiry builds an Inquiry driven by the given backend. Swap in
+// backend/anthropic or backend/ollama (or any other backend.InquiryBackend)
+// to target a different provider without changing anything else here.
+func NewInquiry(llmBackend backend.InquiryBackend, opts ...InquiryOption) *Inquiry {
+	i := &Inquiry{
+		llmBackend:         llmBackend,
 		functionRepertoire: []availableInquiryFunction{},
 		replExports:        map[string]reflect.Value{},
-		messages: []openai.ChatCompletionMessage{
+		policy:             DefaultExecutionPolicy(),
+		messages: []backend.Message{
 			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: "Only use the functions and parameters you have been provided with. Argument responses should take the strict form of a map of numeric keys to string values.",
+				Role:    backend.RoleSystem,
+				Content: "Only use the functions and parameters you have been provided with.",
 			},
 		},
 	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
 }
 
-func (i *Inquiry) AddFunctionToRepertoire(f any, description string) {
+func (i *Inquiry) AddFunctionToRepertoire(f any, description string, opts ...FunctionOption) {
+	var fo functionOptions
+	for _, opt := range opts {
+		opt(&fo)
+	}
+
 	funcName := strings.Split(runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name(), ".")[1]
+	typ := reflect.TypeOf(f)
+
+	paramNames := make([]string, typ.NumIn())
+	paramTypes := make([]reflect.Type, typ.NumIn())
+	for idx := range paramNames {
+		paramTypes[idx] = typ.In(idx)
+		if idx < len(fo.paramNames) && fo.paramNames[idx] != "" {
+			paramNames[idx] = fo.paramNames[idx]
+		} else {
+			paramNames[idx] = fmt.Sprintf("param%d", idx)
+		}
+
+		i.registerStructType(paramTypes[idx])
+	}
 
 	i.replExports[funcName] = reflect.ValueOf(f)
 	i.functionRepertoire = append(i.functionRepertoire, availableInquiryFunction{
 		Name:        funcName,
 		Description: description,
-		FunctionDef: openAIDefinitionForFunction(f, "returns the weather for a given latitude and longitude"),
+		FunctionDef: toolDefinitionForFunction(f, description, paramNames, fo.paramDescriptions),
+		paramNames:  paramNames,
+		paramTypes:  paramTypes,
 	})
 }
 
+// registerStructType makes t's struct types resolvable as provided.<Name>
+// inside evalToolCall's yaegi interpreter (see goTypeExpr), recursing
+// through pointers, slices, and arrays to find them. Non-struct types are
+// a no-op.
+func (i *Inquiry) registerStructType(t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		i.registerStructType(t.Elem())
+	case reflect.Struct:
+		if _, ok := i.replExports[t.Name()]; !ok {
+			// yaegi recognizes a type export by a nil pointer of that
+			// type, the same convention its own stdlib bindings use.
+			i.replExports[t.Name()] = reflect.Zero(reflect.PtrTo(t))
+		}
+	}
+}
+
+// functionByName looks up a registered function by the name the model used
+// in a tool call.
+func (i *Inquiry) functionByName(name string) (availableInquiryFunction, bool) {
+	for _, fn := range i.functionRepertoire {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return availableInquiryFunction{}, false
+}
+
+// Answer runs AnswerStream to completion and returns the fully assembled
+// answer, printing content deltas progressively as they arrive.
 func (i *Inquiry) Answer(ctx context.Context, question string) (string, error) {
-	var answer string
+	deltas, err := i.AnswerStream(ctx, question)
+	if err != nil {
+		return "", err
+	}
+
+	var answer strings.Builder
+	var streamErr error
+
+	for d := range deltas {
+		switch d.Kind {
+		case backend.StreamContent:
+			answer.WriteString(d.Content)
+			fmt.Print(d.Content)
+		case backend.StreamDone:
+			streamErr = d.Err
+		}
+	}
 
-	i.messages = []openai.ChatCompletionMessage{
+	if streamErr != nil {
+		return "", streamErr
+	}
+
+	return answer.String(), nil
+}
+
+// AnswerStream asks the question and streams back the assistant's response
+// as it's generated, transparently running every round of tool calls
+// (stream -> accumulate tool call -> yaegi eval -> next stream) until a
+// final answer comes back. The returned channel is closed once a
+// backend.StreamDone event has been sent.
+func (i *Inquiry) AnswerStream(ctx context.Context, question string) (<-chan backend.StreamEvent, error) {
+	i.messages = []backend.Message{
 		{
-			Role:    openai.ChatMessageRoleSystem,
+			Role:    backend.RoleSystem,
 			Content: "Only use the functions and parameters you have been provided with.",
 		},
 		{
-			Role:    openai.ChatMessageRoleUser,
+			Role:    backend.RoleUser,
 			Content: question,
 		},
 	}
 
-	for answer == "" {
-		if t, ok := ctx.Deadline(); ok && t.Before(time.Now()) {
-			return "", errors.New("context deadline exceeded")
-		}
+	return i.stream(ctx), nil
+}
 
-		latestAnswer, command, err := i.submitQuestion(ctx)
-		if err != nil {
-			return "", err
+// Reply is the result of a single Inquiry.Ask call.
+type Reply struct {
+	// Answer is the assistant's final answer for this turn.
+	Answer string
+	// Messages is the full conversation log Ask saved, including the new
+	// user message, any tool calls run along the way, and the final
+	// answer.
+	Messages []backend.Message
+}
+
+// summarizeThreshold is the fraction of Inquiry.contextWindow a
+// conversation's estimated token count must reach before Ask summarizes
+// its older turns.
+const summarizeThreshold = 0.8
+
+// keepRecentMessages is how many of a conversation's most recent messages
+// summarizeOlderTurns leaves untouched, so the model still has the
+// immediate back-and-forth verbatim.
+const keepRecentMessages = 6
+
+// Ask runs one turn of a persisted, multi-turn conversation: it loads
+// conversationID's prior messages from the ConversationStore set with
+// WithConversationStore, summarizes older turns if the conversation is
+// approaching WithContextWindow's budget, appends userMessage, runs the
+// tool-call loop to a final answer, saves the resulting log, and returns
+// it alongside the answer.
+func (i *Inquiry) Ask(ctx context.Context, conversationID, userMessage string) (Reply, error) {
+	if i.store == nil {
+		return Reply{}, errors.New("wesley: Ask requires a ConversationStore (see WithConversationStore)")
+	}
+
+	loaded, err := i.store.Load(conversationID)
+	if err != nil {
+		return Reply{}, fmt.Errorf("wesley: loading conversation %q: %w", conversationID, err)
+	}
+
+	conv := conversation.New(conversationID, loaded)
+	if len(conv.Messages) == 0 {
+		conv.Append(backend.Message{
+			Role:    backend.RoleSystem,
+			Content: "Only use the functions and parameters you have been provided with.",
+		})
+	}
+
+	if i.contextWindow > 0 && conv.Tokens > int(float64(i.contextWindow)*summarizeThreshold) {
+		if summarizeErr := i.summarizeOlderTurns(ctx, conv); summarizeErr != nil {
+			return Reply{}, summarizeErr
 		}
+	}
 
-		if latestAnswer != "" {
-			answer = latestAnswer
+	conv.Append(backend.Message{Role: backend.RoleUser, Content: userMessage})
+	i.messages = conv.Messages
+
+	var answer strings.Builder
+	var streamErr error
+	for event := range i.stream(ctx) {
+		switch event.Kind {
+		case backend.StreamContent:
+			answer.WriteString(event.Content)
+		case backend.StreamDone:
+			streamErr = event.Err
 		}
+	}
+	if streamErr != nil {
+		return Reply{}, streamErr
+	}
 
-		if command != "" {
-			repl := interp.New(interp.Options{})
-			if err = repl.Use(stdlib.Symbols); err != nil {
-				return "", err
+	if saveErr := i.store.Save(conversationID, i.messages); saveErr != nil {
+		return Reply{}, fmt.Errorf("wesley: saving conversation %q: %w", conversationID, saveErr)
+	}
+
+	return Reply{Answer: answer.String(), Messages: i.messages}, nil
+}
+
+// splitPointBefore returns the largest index <= idx that doesn't fall
+// inside a tool-call round (an assistant message with ToolCalls followed
+// by the RoleTool results answering it), so summarizeOlderTurns never
+// cuts a tool result away from the call that requested it.
+func splitPointBefore(messages []backend.Message, idx int) int {
+	if idx <= 0 {
+		return 0
+	}
+	if idx >= len(messages) {
+		return len(messages)
+	}
+
+	for idx > 0 && messages[idx].Role == backend.RoleTool {
+		idx--
+	}
+
+	return idx
+}
+
+// summarizeOlderTurns replaces every message in conv except the most
+// recent keepRecentMessages with a single system "summary so far" message,
+// built by asking i.summaryBackend (or i.llmBackend, if none was set) to
+// condense them. It's a no-op if there aren't enough older messages to be
+// worth summarizing.
+func (i *Inquiry) summarizeOlderTurns(ctx context.Context, conv *conversation.Conversation) error {
+	if len(conv.Messages) <= keepRecentMessages {
+		return nil
+	}
+
+	splitAt := splitPointBefore(conv.Messages, len(conv.Messages)-keepRecentMessages)
+	if splitAt == 0 {
+		return nil
+	}
+
+	older := conv.Messages[:splitAt]
+	recent := conv.Messages[splitAt:]
+
+	var transcript strings.Builder
+	for _, m := range older {
+		fmt.Fprintf(&transcript, "%s: %s\n", m.Role, m.Content)
+	}
+
+	summarizer := i.summaryBackend
+	if summarizer == nil {
+		summarizer = i.llmBackend
+	}
+
+	resp, err := summarizer.Chat(ctx, backend.ChatRequest{
+		Messages: []backend.Message{
+			{
+				Role:    backend.RoleSystem,
+				Content: "Summarize the following conversation concisely, preserving any facts or decisions a continuation would need.",
+			},
+			{
+				Role:    backend.RoleUser,
+				Content: transcript.String(),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("wesley: summarizing conversation: %w", err)
+	}
+
+	summary := backend.Message{
+		Role:    backend.RoleSystem,
+		Content: "Summary so far: " + resp.Content,
+	}
+
+	conv.Messages = nil
+	conv.Tokens = 0
+	conv.Append(summary)
+	for _, m := range recent {
+		conv.Append(m)
+	}
+
+	return nil
+}
+
+// stream drives the tool-call loop against i.messages as it currently
+// stands, emitting content/tool-call/done events as they happen. Callers
+// seed i.messages first: AnswerStream resets it fresh per question, Ask
+// appends to a loaded conversation.
+func (i *Inquiry) stream(ctx context.Context) <-chan backend.StreamEvent {
+	out := make(chan backend.StreamEvent)
+
+	go func() {
+		defer close(out)
+
+		steps := 0
+		for {
+			if t, ok := ctx.Deadline(); ok && t.Before(time.Now()) {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: errors.New("context deadline exceeded")}
+				return
 			}
 
-			if err = repl.Use(interp.Exports{"provided/provided": i.replExports}); err != nil {
-				return "", err
+			steps++
+			if i.policy.MaxSteps > 0 && steps > i.policy.MaxSteps {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: fmt.Errorf("wesley: exceeded max tool-call steps (%d)", i.policy.MaxSteps)}
+				return
 			}
 
-			if _, err = repl.Eval(`import "provided"`); err != nil {
-				return "", err
+			content, assistantMessage, toolCalls, err := i.submitQuestionStream(ctx, out)
+			if err != nil {
+				out <- backend.StreamEvent{Kind: backend.StreamDone, Err: err}
+				return
 			}
 
-			script := fmt.Sprintf(`provided.%s`, command)
+			if content != "" {
+				i.messages = append(i.messages, backend.Message{Role: backend.RoleAssistant, Content: content})
+				out <- backend.StreamEvent{Kind: backend.StreamDone}
+				return
+			}
 
-			var outcome reflect.Value
-			outcome, err = repl.Eval(script)
-			if err != nil {
-				return "", err
+			i.messages = append(i.messages, assistantMessage)
+			i.messages = append(i.messages, i.executeToolCalls(ctx, toolCalls)...)
+		}
+	}()
+
+	return out
+}
+
+// executeToolCalls runs every tool call returned by the model through yaegi
+// in parallel, bounded by maxParallelToolCalls, and returns one
+// backend.RoleTool message per call, in the same order the calls arrived
+// in, with ToolCallID wired through so the model can match results back up.
+func (i *Inquiry) executeToolCalls(ctx context.Context, toolCalls []backend.ToolCall) []backend.Message {
+	results := make([]backend.Message, len(toolCalls))
+
+	sem := make(chan struct{}, maxParallelToolCalls)
+	var wg sync.WaitGroup
+
+	for idx, call := range toolCalls {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(idx int, call backend.ToolCall) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			outcome, evalErr := i.evalToolCall(ctx, call)
+			if evalErr != nil {
+				outcome = evalErr.Error()
+			}
+
+			results[idx] = backend.Message{
+				Role:       backend.RoleTool,
+				Content:    outcome,
+				Name:       call.Name,
+				ToolCallID: call.ID,
+			}
+		}(idx, call)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// evalToolCall parses a single tool call's named arguments and runs it
+// through a fresh yaegi interpreter restricted by i.policy, returning its
+// string result. Failures come back as a *ToolExecError rather than a
+// bare error, carrying whatever stdout/stderr the script produced, so the
+// caller can feed a structured result back to the model instead of
+// aborting the whole Answer.
+func (i *Inquiry) evalToolCall(ctx context.Context, call backend.ToolCall) (string, *ToolExecError) {
+	fn, ok := i.functionByName(call.Name)
+	if !ok {
+		return "", &ToolExecError{FunctionName: call.Name, Err: fmt.Errorf("wesley: unknown function %q", call.Name)}
+	}
+
+	var rawArgs map[string]json.RawMessage
+	if unmarshalErr := json.Unmarshal([]byte(call.Arguments), &rawArgs); unmarshalErr != nil {
+		return "", &ToolExecError{FunctionName: call.Name, Err: unmarshalErr}
+	}
+
+	args := make([]string, len(fn.paramNames))
+	for idx, name := range fn.paramNames {
+		raw, present := rawArgs[name]
+		if !present {
+			if fn.paramTypes[idx].Kind() == reflect.Ptr {
+				args[idx] = "nil"
+				continue
 			}
+			return "", &ToolExecError{FunctionName: call.Name, Err: fmt.Errorf("wesley: missing required argument %q for %q", name, call.Name)}
+		}
 
-			i.messages = append(i.messages, openai.ChatCompletionMessage{
-				Role:    openai.ChatMessageRoleFunction,
-				Content: outcome.String(),
-				Name:    strings.Split(command, "(")[0],
-			})
+		literal, literalErr := goLiteralForValue(raw, fn.paramTypes[idx])
+		if literalErr != nil {
+			return "", &ToolExecError{FunctionName: call.Name, Err: fmt.Errorf("wesley: argument %q for %q: %w", name, call.Name, literalErr)}
 		}
+		args[idx] = literal
 	}
 
-	return answer, nil
+	script := fmt.Sprintf(`%s(%s)`, call.Name, strings.Join(args, ", "))
+
+	var stdout, stderr bytes.Buffer
+	replOpts := interp.Options{}
+	if i.policy.CaptureOutput {
+		replOpts.Stdout = &stdout
+		replOpts.Stderr = &stderr
+	}
+
+	fail := func(err error) (string, *ToolExecError) {
+		return "", &ToolExecError{FunctionName: call.Name, Err: err, Stdout: stdout.String(), Stderr: stderr.String()}
+	}
+
+	repl := interp.New(replOpts)
+	if err := repl.Use(i.policy.Symbols); err != nil {
+		return fail(err)
+	}
+
+	if err := repl.Use(interp.Exports{"provided/provided": i.replExports}); err != nil {
+		return fail(err)
+	}
+
+	if _, err := repl.Eval(`import "provided"`); err != nil {
+		return fail(err)
+	}
+
+	evalCtx := ctx
+	if i.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		evalCtx, cancel = context.WithTimeout(ctx, i.policy.Timeout)
+		defer cancel()
+	}
+
+	outcome, err := repl.EvalWithContext(evalCtx, fmt.Sprintf(`provided.%s`, script))
+	if err != nil {
+		return fail(err)
+	}
+
+	result := outcome.String()
+	if i.policy.CaptureOutput && (stdout.Len() > 0 || stderr.Len() > 0) {
+		result = fmt.Sprintf("%s\n[stdout]: %s\n[stderr]: %s", result, stdout.String(), stderr.String())
+	}
+
+	return result, nil
 }
 
-func (i *Inquiry) submitQuestion(ctx context.Context) (answer string, functionCall string, err error) {
-	log.Println("making request to Open AI")
+// buildRequest assembles the neutral ChatRequest for the current message
+// log and registered functions. Translating that into whatever shape a
+// specific provider's API wants is the backend's job.
+func (i *Inquiry) buildRequest() backend.ChatRequest {
+	tools := make([]backend.ToolDefinition, len(i.functionRepertoire))
+	for idx, x := range i.functionRepertoire {
+		tools[idx] = x.FunctionDef
+	}
 
-	funcDefs := []openai.FunctionDefinition{}
-	for _, x := range i.functionRepertoire {
-		funcDefs = append(funcDefs, x.FunctionDef)
+	var toolChoice string
+	if len(tools) > 0 {
+		toolChoice = "auto"
 	}
 
-	result, completionErr := i.openaiClient.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model:       openai.GPT3Dot5Turbo16K0613,
-		Functions:   funcDefs,
+	return backend.ChatRequest{
+		Messages:    i.messages,
+		Tools:       tools,
+		ToolChoice:  toolChoice,
 		Temperature: 1.0,
 		TopP:        1.0,
-		Messages:    i.messages,
-	})
-	if completionErr != nil {
-		return "", "", err
 	}
+}
+
+// submitQuestionStream streams the backend's response to the current
+// message log, forwarding content and tool-call deltas to out as they
+// arrive. It returns once the backend's stream ends, reporting either a
+// final answer or the assistant message plus tool calls to execute next.
+func (i *Inquiry) submitQuestionStream(ctx context.Context, out chan<- backend.StreamEvent) (answer string, assistantMessage backend.Message, toolCalls []backend.ToolCall, err error) {
+	log.Println("making streaming request to LLM backend")
+
+	events, err := i.llmBackend.ChatStream(ctx, i.buildRequest())
+	if err != nil {
+		return "", backend.Message{}, nil, err
+	}
+
+	var content strings.Builder
+	toolCallsByID := map[string]*backend.ToolCall{}
+	var toolCallOrder []string
 
-	if len(result.Choices) > 0 {
-		firstChoice := result.Choices[0]
-		if firstChoice.FinishReason == "function_call" {
-			var rawArgs map[int]string
-			argsToUnmarshal := firstChoice.Message.FunctionCall.Arguments
-			if unmarshalErr := json.Unmarshal([]byte(argsToUnmarshal), &rawArgs); unmarshalErr != nil {
-				return "", "", unmarshalErr
+	for event := range events {
+		switch event.Kind {
+		case backend.StreamContent:
+			content.WriteString(event.Content)
+			out <- event
+
+		case backend.StreamToolCall:
+			if _, ok := toolCallsByID[event.ToolCall.ID]; !ok {
+				toolCallOrder = append(toolCallOrder, event.ToolCall.ID)
 			}
+			call := event.ToolCall
+			toolCallsByID[event.ToolCall.ID] = &call
+			out <- event
 
-			args := make([]string, len(rawArgs))
-			for k, v := range rawArgs {
-				args[k] = fmt.Sprintf("%q", v)
+		case backend.StreamDone:
+			if event.Err != nil {
+				return "", backend.Message{}, nil, event.Err
 			}
+		}
+	}
+
+	if len(toolCallOrder) == 0 {
+		return content.String(), backend.Message{}, nil, nil
+	}
 
-			script := fmt.Sprintf(`%s(%s)`, firstChoice.Message.FunctionCall.Name, strings.Join(args, ", "))
+	toolCalls = make([]backend.ToolCall, len(toolCallOrder))
+	for idx, id := range toolCallOrder {
+		toolCalls[idx] = *toolCallsByID[id]
+	}
 
-			return "", script, nil
-		} else {
-			return firstChoice.Message.Content, "", nil
-		}
+	assistantMessage = backend.Message{
+		Role:      backend.RoleAssistant,
+		ToolCalls: toolCalls,
 	}
 
-	return "", "", errors.New("no choices returned")
+	return "", assistantMessage, toolCalls, nil
 }
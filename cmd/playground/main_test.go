@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+type literalTestStruct struct {
+	Name string `json:"name"`
+}
+
+func TestGoTypeExpr(t *testing.T) {
+	tests := []struct {
+		name string
+		t    reflect.Type
+		want string
+	}{
+		{"struct", reflect.TypeOf(literalTestStruct{}), "provided.literalTestStruct"},
+		{"ptr to struct", reflect.TypeOf(&literalTestStruct{}), "*provided.literalTestStruct"},
+		{"string", reflect.TypeOf(""), "string"},
+		{"ptr to string", reflect.TypeOf(new(string)), "*string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goTypeExpr(tt.t); got != tt.want {
+				t.Errorf("goTypeExpr(%v) = %q, want %q", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoLiteralForValue(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		t    reflect.Type
+		want string
+	}{
+		{
+			name: "slice of struct",
+			raw:  `[{"name":"a"},{"name":"b"}]`,
+			t:    reflect.TypeOf([]literalTestStruct{}),
+			want: `[]provided.literalTestStruct{provided.literalTestStruct{Name: "a"}, provided.literalTestStruct{Name: "b"}}`,
+		},
+		{
+			name: "slice of pointer to struct",
+			raw:  `[{"name":"a"}]`,
+			t:    reflect.TypeOf([]*literalTestStruct{}),
+			want: `[]*provided.literalTestStruct{func() *provided.literalTestStruct { v := provided.literalTestStruct{Name: "a"}; return &v }()}`,
+		},
+		{
+			name: "array of string",
+			raw:  `["a","b"]`,
+			t:    reflect.TypeOf([2]string{}),
+			want: `[2]string{"a", "b"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goLiteralForValue(json.RawMessage(tt.raw), tt.t)
+			if err != nil {
+				t.Fatalf("goLiteralForValue: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("goLiteralForValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func namesFromItems(items []*literalTestStruct) string {
+	out := ""
+	for _, item := range items {
+		out += item.Name
+	}
+	return out
+}
+
+// TestEvalToolCallPointerSliceArgument exercises the full evalToolCall path
+// for a []*Struct parameter, the combination goTypeExpr's Ptr recursion
+// fixes: a literal that only special-cased bare structs would reference
+// main.literalTestStruct here instead of provided.literalTestStruct, which
+// yaegi can't resolve.
+func TestEvalToolCallPointerSliceArgument(t *testing.T) {
+	i := NewInquiry(nil, WithExecutionPolicy(ExecutionPolicy{Timeout: 5 * time.Second}))
+
+	// Registered directly (bypassing AddFunctionToRepertoire's
+	// runtime.FuncForPC-derived name) since under `go test` the test
+	// binary reports this package's functions under their full module
+	// path rather than "main", which that name-derivation isn't meant to
+	// handle.
+	paramType := reflect.TypeOf([]*literalTestStruct(nil))
+	i.registerStructType(paramType)
+	i.replExports["namesFromItems"] = reflect.ValueOf(namesFromItems)
+	i.functionRepertoire = append(i.functionRepertoire, availableInquiryFunction{
+		Name:       "namesFromItems",
+		paramNames: []string{"items"},
+		paramTypes: []reflect.Type{paramType},
+	})
+
+	result, execErr := i.evalToolCall(context.Background(), backend.ToolCall{
+		Name:      "namesFromItems",
+		Arguments: `{"items":[{"name":"a"},{"name":"b"}]}`,
+	})
+	if execErr != nil {
+		t.Fatalf("evalToolCall: %v", execErr.Err)
+	}
+	if result != "ab" {
+		t.Errorf("evalToolCall() = %q, want %q", result, "ab")
+	}
+}
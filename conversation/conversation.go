@@ -0,0 +1,56 @@
+// Package conversation holds the types that let an Inquiry's message log
+// survive across process restarts: a Conversation with token-budget
+// accounting, and a ConversationStore interface implementations in
+// sibling packages (memory, file, sqlite) persist it through.
+package conversation
+
+import "github.com/verygoodsoftwarenotvirus/wesley/backend"
+
+// Conversation is a growing message log plus a running token-budget
+// estimate, the unit ConversationStore persists and Inquiry.Ask resumes.
+type Conversation struct {
+	ID       string
+	Messages []backend.Message
+	Tokens   int
+}
+
+// New builds a Conversation from a message log already loaded from a
+// ConversationStore, recomputing its token estimate.
+func New(id string, messages []backend.Message) *Conversation {
+	c := &Conversation{ID: id}
+	for _, m := range messages {
+		c.Append(m)
+	}
+	return c
+}
+
+// Append adds a message to the conversation, updating the token
+// estimate.
+func (c *Conversation) Append(m backend.Message) {
+	c.Messages = append(c.Messages, m)
+	c.Tokens += EstimateTokens(m)
+}
+
+// EstimateTokens gives a cheap, provider-agnostic token estimate for a
+// single message (roughly four characters per token), good enough to
+// decide when a conversation is approaching a model's context window
+// without calling out to a provider-specific tokenizer.
+func EstimateTokens(m backend.Message) int {
+	chars := len(m.Content)
+	for _, tc := range m.ToolCalls {
+		chars += len(tc.Name) + len(tc.Arguments)
+	}
+	return chars/4 + 1
+}
+
+// ConversationStore loads and saves a Conversation's message log, keyed
+// by conversation ID. Implementations live in sibling packages:
+// conversation/memory, conversation/file (JSONL), and
+// conversation/sqlite.
+type ConversationStore interface {
+	// Load returns the messages previously saved under id, or a nil slice
+	// if nothing has been saved yet.
+	Load(id string) ([]backend.Message, error)
+	// Save replaces whatever was previously saved under id.
+	Save(id string, messages []backend.Message) error
+}
@@ -0,0 +1,125 @@
+// Package file implements conversation.ConversationStore with one JSONL
+// file per conversation, one backend.Message per line.
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+// Store is a conversation.ConversationStore backed by one JSONL file per
+// conversation ID under Dir.
+type Store struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// New builds a Store that reads and writes conversation files under dir.
+// dir is created on first Save if it doesn't already exist.
+func New(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// path joins id onto Dir, rejecting ids that would let a conversation ID
+// (caller/session-supplied, in the chatbot case this Store is meant for)
+// escape Dir via path separators or "..".
+func (s *Store) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("conversation/file: invalid conversation id %q", id)
+	}
+	return filepath.Join(s.Dir, id+".jsonl"), nil
+}
+
+func (s *Store) Load(id string) ([]backend.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var messages []backend.Message
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var m backend.Message
+		if unmarshalErr := json.Unmarshal(scanner.Bytes(), &m); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		messages = append(messages, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// Save writes messages out atomically: it writes to a temp file in Dir
+// and renames it over the conversation's file, so a crash mid-write never
+// leaves a truncated conversation behind.
+func (s *Store) Save(id string, messages []backend.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	finalPath, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	tmpPath := finalPath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, m := range messages {
+		encoded, marshalErr := json.Marshal(m)
+		if marshalErr != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return marshalErr
+		}
+		if _, writeErr := w.Write(append(encoded, '\n')); writeErr != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return writeErr
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, finalPath)
+}
@@ -0,0 +1,45 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+func TestStoreRejectsPathTraversal(t *testing.T) {
+	s := New(t.TempDir())
+
+	ids := []string{"../escape-test", "a/b", `a\b`, "..", "."}
+	for _, id := range ids {
+		if err := s.Save(id, nil); err == nil {
+			t.Errorf("Save(%q) = nil error, want error", id)
+		}
+		if _, err := s.Load(id); err == nil {
+			t.Errorf("Load(%q) = nil error, want error", id)
+		}
+	}
+}
+
+func TestStoreSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir)
+
+	messages := []backend.Message{{Role: backend.RoleUser, Content: "hi"}}
+	if err := s.Save("conv-1", messages); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "conv-1.jsonl")); err != nil {
+		t.Fatalf("expected conversation file under Dir, got: %v", err)
+	}
+
+	got, err := s.Load("conv-1")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got) != 1 || got[0].Content != "hi" {
+		t.Fatalf("Load() = %+v, want round-tripped messages", got)
+	}
+}
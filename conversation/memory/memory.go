@@ -0,0 +1,36 @@
+// Package memory implements conversation.ConversationStore with an
+// in-memory map, useful for tests and single-process deployments that
+// don't need history to survive a restart.
+package memory
+
+import (
+	"sync"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+// Store is an in-memory conversation.ConversationStore.
+type Store struct {
+	mu            sync.Mutex
+	conversations map[string][]backend.Message
+}
+
+// New builds an empty Store.
+func New() *Store {
+	return &Store{conversations: map[string][]backend.Message{}}
+}
+
+func (s *Store) Load(id string) ([]backend.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]backend.Message(nil), s.conversations[id]...), nil
+}
+
+func (s *Store) Save(id string, messages []backend.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.conversations[id] = append([]backend.Message(nil), messages...)
+	return nil
+}
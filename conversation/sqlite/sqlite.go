@@ -0,0 +1,79 @@
+// Package sqlite implements conversation.ConversationStore against a
+// SQLite database, storing each conversation's message log as a single
+// JSON blob keyed by conversation ID.
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/verygoodsoftwarenotvirus/wesley/backend"
+)
+
+// Store is a conversation.ConversationStore backed by a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// New opens (creating if necessary) the SQLite database at path and
+// ensures its schema exists.
+func New(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS conversations (
+			id       TEXT PRIMARY KEY,
+			messages TEXT NOT NULL
+		)
+	`); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Load(id string) ([]backend.Message, error) {
+	var encoded string
+	err := s.db.QueryRow(`SELECT messages FROM conversations WHERE id = ?`, id).Scan(&encoded)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []backend.Message
+	if err := json.Unmarshal([]byte(encoded), &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (s *Store) Save(id string, messages []backend.Message) error {
+	encoded, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.Exec(`
+		INSERT INTO conversations (id, messages) VALUES (?, ?)
+		ON CONFLICT(id) DO UPDATE SET messages = excluded.messages
+	`, id, string(encoded)); err != nil {
+		return fmt.Errorf("wesley: saving conversation %q: %w", id, err)
+	}
+
+	return nil
+}